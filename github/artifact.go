@@ -0,0 +1,350 @@
+package github
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/packwiz/packwiz/core"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ghArtifactUpdateData is the `update` metadata stored for mods installed from a workflow
+// artifact rather than a release asset. Unlike releases (which are addressed by tag) or
+// source checkouts (addressed by commit SHA), an artifact is addressed by the workflow run
+// that produced it, since artifacts themselves don't carry a stable identifier.
+type ghArtifactUpdateData struct {
+	Slug         string `json:"slug"`
+	Workflow     string `json:"workflow"`
+	Branch       string `json:"branch"`
+	RunID        int64  `json:"runId"`
+	ArtifactName string `json:"artifactName"`
+}
+
+func (u ghArtifactUpdateData) ToMap() (map[string]interface{}, error) {
+	var m map[string]interface{}
+	data, err := json.Marshal(u)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &m)
+	return m, err
+}
+
+type workflowRun struct {
+	ID         int64  `json:"id"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	HeadBranch string `json:"head_branch"`
+	Path       string `json:"path"`
+}
+
+type workflowRunsResponse struct {
+	WorkflowRuns []workflowRun `json:"workflow_runs"`
+}
+
+type artifact struct {
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	ArchiveDownloadURL string `json:"archive_download_url"`
+	Expired            bool   `json:"expired"`
+}
+
+type artifactsResponse struct {
+	Artifacts []artifact `json:"artifacts"`
+}
+
+// addArtifactCmd represents the "github add-artifact" command.
+var addArtifactCmd = &cobra.Command{
+	Use:   "add-artifact [URL]",
+	Short: "Add a project from a GitHub Actions workflow artifact (for repos with no releases)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		pack, err := core.LoadPack()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		var slug string
+		matches := GithubRegex.FindStringSubmatch(args[0])
+		if len(matches) == 2 {
+			slug = matches[1]
+		} else {
+			slug = args[0]
+		}
+
+		workflow, err := cmd.Flags().GetString("workflow")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		branch, err := cmd.Flags().GetString("branch")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		artifactName, err := cmd.Flags().GetString("artifact")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		repo, err := fetchRepo(slug)
+		if err != nil {
+			fmt.Println("Failed to get the mod ", err)
+			os.Exit(1)
+		}
+
+		err = installArtifact(repo, workflow, branch, artifactName, pack)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	addArtifactCmd.Flags().String("workflow", "", "Workflow file to pick the latest successful run from (e.g. ci.yml); defaults to any workflow")
+	addArtifactCmd.Flags().String("branch", "main", "Branch to pick the latest successful run from")
+	addArtifactCmd.Flags().String("artifact", "", "Name of the artifact to install; defaults to the first artifact on the run")
+	githubCmd.AddCommand(addArtifactCmd)
+}
+
+// installArtifact finds the latest successful run of workflow on branch, downloads the named
+// artifact (artifacts require an authenticated request, unlike release assets), and records
+// enough of the run's identity in ghArtifactUpdateData for `update` to notice newer runs.
+func installArtifact(repo Repo, workflow string, branch string, artifactName string, pack core.Pack) error {
+	run, art, jarHash, jarContent, jarName, err := resolveArtifact(repo.FullName, workflow, branch, artifactName)
+	if err != nil {
+		return err
+	}
+
+	folder := modFolder()
+	if err := writeModFile(folder, jarName, jarContent); err != nil {
+		return fmt.Errorf("failed to write %q: %v", jarName, err)
+	}
+
+	fmt.Printf("Installing %s from artifact %s (run #%d)\n", jarName, art.Name, run.ID)
+
+	index, err := pack.LoadIndex()
+	if err != nil {
+		return err
+	}
+
+	// Recorded under its own key, not "github" - an artifact-installed mod's metadata
+	// (run/workflow) is structurally incompatible with a release/source install's (tag/SHA),
+	// and the two must not collide under the same update key.
+	updateMap := make(map[string]map[string]interface{})
+	updateMap["github-artifact"], err = ghArtifactUpdateData{
+		Slug:         repo.FullName,
+		Workflow:     workflow,
+		Branch:       branch,
+		RunID:        run.ID,
+		ArtifactName: art.Name,
+	}.ToMap()
+	if err != nil {
+		return err
+	}
+
+	// ArchiveDownloadURL only ever serves the whole zip, never the bare jar that's actually
+	// installed, so there's no fetchable URL that reproduces FileName's bytes. Hash is of the
+	// jar itself (what's on disk, for the pack's own integrity record) - a fresh `install`
+	// re-fetching URL would get the whole zip and fail the hash check, the same known gap
+	// materializeSource's subdir case has.
+	modMeta := core.Mod{
+		Name:     repo.Name,
+		FileName: jarName,
+		Side:     core.UniversalSide,
+		Download: core.ModDownload{
+			URL:        art.ArchiveDownloadURL,
+			HashFormat: "sha256",
+			Hash:       jarHash,
+		},
+		Update: updateMap,
+	}
+
+	path := modMeta.SetMetaPath(filepath.Join(viper.GetString("meta-folder-base"), folder, repo.Name+core.MetaExtension))
+
+	format, hash, err := modMeta.Write()
+	if err != nil {
+		return err
+	}
+	err = index.RefreshFileWithHash(path, format, hash, true)
+	if err != nil {
+		return err
+	}
+	err = index.Write()
+	if err != nil {
+		return err
+	}
+	err = pack.UpdateIndexHash()
+	if err != nil {
+		return err
+	}
+	return pack.Write()
+}
+
+// resolveArtifact finds the latest successful run of workflow on branch, downloads its
+// artifact (by name, or the first one), and unzips the jar inside it. It's shared by
+// installArtifact and updateGithubArtifact so both resolve "the current artifact" identically
+// - always against the live API, never against a cached run/artifact URL, since artifact
+// download URLs expire after 90 days. The returned hash is of the extracted jar itself, not the
+// zip - the jar is what ends up on disk, and Download.Hash must describe that.
+func resolveArtifact(slug string, workflow string, branch string, artifactName string) (workflowRun, artifact, string, []byte, string, error) {
+	run, err := latestSuccessfulRun(slug, workflow, branch)
+	if err != nil {
+		return workflowRun{}, artifact{}, "", nil, "", fmt.Errorf("failed to find a successful workflow run: %v", err)
+	}
+
+	art, err := findRunArtifact(slug, run.ID, artifactName)
+	if err != nil {
+		return workflowRun{}, artifact{}, "", nil, "", fmt.Errorf("failed to find artifact: %v", err)
+	}
+	if art.Expired {
+		return workflowRun{}, artifact{}, "", nil, "", errors.New("the selected artifact has expired; re-run the workflow to produce a fresh one")
+	}
+
+	zipPath, err := downloadArtifactZip(art)
+	if err != nil {
+		return workflowRun{}, artifact{}, "", nil, "", err
+	}
+	defer os.Remove(zipPath)
+
+	jarContent, jarName, err := extractJarFromZip(zipPath)
+	if err != nil {
+		return workflowRun{}, artifact{}, "", nil, "", err
+	}
+
+	jarHasher := sha256.New()
+	jarHasher.Write(jarContent)
+
+	return run, art, hex.EncodeToString(jarHasher.Sum(nil)), jarContent, jarName, nil
+}
+
+func latestSuccessfulRun(slug string, workflow string, branch string) (workflowRun, error) {
+	path := fmt.Sprintf("repos/%s/actions/runs?branch=%s&status=success", slug, branch)
+	if workflow != "" {
+		path = fmt.Sprintf("repos/%s/actions/workflows/%s/runs?branch=%s&status=success", slug, workflow, branch)
+	}
+
+	resp, err := ghDefaultClient.makeGet(path)
+	if err != nil {
+		return workflowRun{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return workflowRun{}, err
+	}
+
+	var runs workflowRunsResponse
+	if err := json.Unmarshal(body, &runs); err != nil {
+		return workflowRun{}, err
+	}
+	if len(runs.WorkflowRuns) == 0 {
+		return workflowRun{}, errors.New("no successful workflow runs found")
+	}
+	return runs.WorkflowRuns[0], nil
+}
+
+func findRunArtifact(slug string, runID int64, artifactName string) (artifact, error) {
+	resp, err := ghDefaultClient.makeGet(fmt.Sprintf("repos/%s/actions/runs/%d/artifacts", slug, runID))
+	if err != nil {
+		return artifact{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return artifact{}, err
+	}
+
+	var resBody artifactsResponse
+	if err := json.Unmarshal(body, &resBody); err != nil {
+		return artifact{}, err
+	}
+	if len(resBody.Artifacts) == 0 {
+		return artifact{}, errors.New("the workflow run has no artifacts attached")
+	}
+
+	if artifactName == "" {
+		return resBody.Artifacts[0], nil
+	}
+	for _, a := range resBody.Artifacts {
+		if a.Name == artifactName {
+			return a, nil
+		}
+	}
+	return artifact{}, fmt.Errorf("no artifact named %q found on this run", artifactName)
+}
+
+// downloadArtifactZip downloads art's archive (artifacts always require an authenticated
+// request, even on public repos) to a temporary file and returns its path. The zip itself is
+// just a transport container here - extractJarFromZip's caller hashes the jar it unwraps, not
+// this file. Fetched via makeGetBinary, not makeGet: the archive is a multi-megabyte blob, and
+// the blob storage backing it commonly returns an ETag, which would otherwise land the whole
+// zip in the on-disk API response cache.
+func downloadArtifactZip(art artifact) (path string, err error) {
+	resp, err := ghDefaultClient.makeGetBinary(art.ArchiveDownloadURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download artifact: status %s", resp.Status)
+	}
+
+	tmpFile, err := os.CreateTemp("", "packwiz-github-artifact-*.zip")
+	if err != nil {
+		return "", err
+	}
+	defer tmpFile.Close()
+
+	if _, err := io.Copy(tmpFile, resp.Body); err != nil {
+		os.Remove(tmpFile.Name())
+		return "", err
+	}
+	return tmpFile.Name(), nil
+}
+
+// extractJarFromZip unwraps the first .jar found in the artifact zip at zipPath, returning
+// its raw bytes and name, since artifacts are always zips even when they contain a single jar.
+func extractJarFromZip(zipPath string) (content []byte, name string, err error) {
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open artifact zip: %v", err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if !strings.HasSuffix(f.Name, ".jar") {
+			continue
+		}
+
+		src, err := f.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		defer src.Close()
+
+		data, err := io.ReadAll(src)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return data, filepath.Base(f.Name), nil
+	}
+
+	return nil, "", errors.New("artifact does not contain a .jar file")
+}
@@ -0,0 +1,186 @@
+package github
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// defaultAssetExcludes are substrings commonly used by projects to mark "secondary" jars
+// (sources/dev/slim builds) that we should steer clear of when the user hasn't given us
+// any other way to disambiguate.
+var defaultAssetExcludes = []string{"-sources", "-dev", "-slim", "-javadoc"}
+
+// regexOnlyMetachars are metacharacters that mean something in a regex but either nothing or
+// something different in a glob; their presence is what tells matchAssetPattern to treat the
+// pattern as a regex instead of a glob.
+const regexOnlyMetachars = `^$()|+\`
+
+// matchAssetPattern narrows assets down to those whose Name matches pattern. The pattern is
+// tried as a glob by default (the common case, e.g. "*-fabric-*.jar"); if it contains a
+// character that's meaningless in a glob but meaningful in a regex, it's compiled as a regex
+// instead. An empty pattern matches everything.
+func matchAssetPattern(assets []Asset, pattern string) ([]Asset, error) {
+	if pattern == "" {
+		return assets, nil
+	}
+
+	if strings.ContainsAny(pattern, regexOnlyMetachars) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid regex: %v", pattern, err)
+		}
+		var matched []Asset
+		for _, a := range assets {
+			if re.MatchString(a.Name) {
+				matched = append(matched, a)
+			}
+		}
+		return matched, nil
+	}
+
+	var matched []Asset
+	for _, a := range assets {
+		ok, err := filepath.Match(pattern, a.Name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset pattern %q: %v", pattern, err)
+		}
+		if ok {
+			matched = append(matched, a)
+		}
+	}
+	return matched, nil
+}
+
+// applyAssetPreference narrows assets down using a --prefer hint, a substring that must
+// appear in the asset name (case-insensitive), e.g. a loader like "fabric" or "forge". If
+// prefer is empty, the common "secondary" jars (sources/dev/slim) are excluded instead, as
+// long as doing so doesn't remove every candidate.
+func applyAssetPreference(assets []Asset, prefer string) []Asset {
+	if prefer != "" {
+		var preferred []Asset
+		for _, a := range assets {
+			if strings.Contains(strings.ToLower(a.Name), strings.ToLower(prefer)) {
+				preferred = append(preferred, a)
+			}
+		}
+		if len(preferred) > 0 {
+			return preferred
+		}
+		return assets
+	}
+
+	var filtered []Asset
+	for _, a := range assets {
+		excluded := false
+		lowerName := strings.ToLower(a.Name)
+		for _, substr := range defaultAssetExcludes {
+			if strings.Contains(lowerName, substr) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			filtered = append(filtered, a)
+		}
+	}
+	if len(filtered) > 0 {
+		return filtered
+	}
+	return assets
+}
+
+// jarAssets returns only the assets that look like installable jar files, if there are any;
+// otherwise it returns the input unchanged (e.g. for repos that ship zips).
+func jarAssets(assets []Asset) []Asset {
+	var jars []Asset
+	for _, a := range assets {
+		if strings.HasSuffix(a.Name, ".jar") {
+			jars = append(jars, a)
+		}
+	}
+	if len(jars) == 0 {
+		return assets
+	}
+	return jars
+}
+
+// chooseAsset resolves a release's assets down to exactly one, using the given pattern/prefer
+// hints, and falling back to an interactive prompt when more than one candidate remains. In
+// yes mode, remaining ambiguity is an error instead of a prompt.
+func chooseAsset(assets []Asset, pattern string, prefer string, yes bool) (Asset, error) {
+	if len(assets) == 0 {
+		return Asset{}, errors.New("release doesn't have any files attached")
+	}
+
+	// An explicit pattern is matched against every asset, not just jars - the user may be
+	// deliberately selecting a non-jar asset (e.g. a zip). Only pre-filter to jars when there's
+	// no pattern to guide the match.
+	candidates := assets
+	if pattern == "" {
+		candidates = jarAssets(candidates)
+	}
+
+	matched, err := matchAssetPattern(candidates, pattern)
+	if err != nil {
+		return Asset{}, err
+	}
+	if pattern != "" {
+		candidates = matched
+	}
+	if len(candidates) == 0 {
+		return Asset{}, fmt.Errorf("no assets matched pattern %q", pattern)
+	}
+
+	candidates = applyAssetPreference(candidates, prefer)
+
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	if yes {
+		names := make([]string, len(candidates))
+		for i, a := range candidates {
+			names[i] = a.Name
+		}
+		return Asset{}, fmt.Errorf("%d assets are still ambiguous after filtering (%s); use --asset-pattern or --prefer to disambiguate", len(candidates), strings.Join(names, ", "))
+	}
+
+	return promptAssetChoice(candidates)
+}
+
+// promptAssetChoice asks the user to pick one of several ambiguous assets on the terminal.
+func promptAssetChoice(assets []Asset) (Asset, error) {
+	fmt.Println("Multiple assets matched; please choose one:")
+	for i, a := range assets {
+		fmt.Printf("[%d] %s\n", i+1, a.Name)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Print("Selection: ")
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return Asset{}, fmt.Errorf("failed to read selection: %v", err)
+		}
+		idx, err := parseAssetSelection(strings.TrimSpace(line), len(assets))
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		return assets[idx], nil
+	}
+}
+
+// parseAssetSelection validates a 1-based selection typed by the user, returning a 0-based index.
+func parseAssetSelection(input string, count int) (int, error) {
+	var idx int
+	if _, err := fmt.Sscanf(input, "%d", &idx); err != nil || idx < 1 || idx > count {
+		return 0, fmt.Errorf("please enter a number between 1 and %d", count)
+	}
+	return idx - 1, nil
+}
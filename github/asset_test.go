@@ -0,0 +1,139 @@
+package github
+
+import "testing"
+
+func assetNames(assets []Asset) []string {
+	names := make([]string, len(assets))
+	for i, a := range assets {
+		names[i] = a.Name
+	}
+	return names
+}
+
+func TestMatchAssetPatternGlob(t *testing.T) {
+	assets := []Asset{
+		{Name: "mod-fabric-1.20.jar"},
+		{Name: "mod-forge-1.20.jar"},
+		{Name: "mod-sources.jar"},
+	}
+
+	matched, err := matchAssetPattern(assets, "*-fabric-*.jar")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(matched) != 1 || matched[0].Name != "mod-fabric-1.20.jar" {
+		t.Fatalf("expected a single fabric match, got %v", assetNames(matched))
+	}
+}
+
+func TestMatchAssetPatternRegex(t *testing.T) {
+	assets := []Asset{
+		{Name: "mod-fabric-1.20.jar"},
+		{Name: "mod-forge-1.20.jar"},
+	}
+
+	matched, err := matchAssetPattern(assets, "^mod-(fabric|forge)-1\\.20\\.jar$")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(matched) != 2 {
+		t.Fatalf("expected both assets to match, got %v", assetNames(matched))
+	}
+}
+
+func TestMatchAssetPatternEmpty(t *testing.T) {
+	assets := []Asset{{Name: "mod.jar"}}
+	matched, err := matchAssetPattern(assets, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(matched) != 1 {
+		t.Fatalf("expected no filtering for an empty pattern, got %v", assetNames(matched))
+	}
+}
+
+func TestApplyAssetPreferenceHint(t *testing.T) {
+	assets := []Asset{
+		{Name: "mod-fabric.jar"},
+		{Name: "mod-forge.jar"},
+	}
+
+	preferred := applyAssetPreference(assets, "forge")
+	if len(preferred) != 1 || preferred[0].Name != "mod-forge.jar" {
+		t.Fatalf("expected forge preference to narrow to one asset, got %v", assetNames(preferred))
+	}
+}
+
+func TestApplyAssetPreferenceExcludesSecondaryJars(t *testing.T) {
+	assets := []Asset{
+		{Name: "mod-1.0.jar"},
+		{Name: "mod-1.0-sources.jar"},
+		{Name: "mod-1.0-dev.jar"},
+	}
+
+	filtered := applyAssetPreference(assets, "")
+	if len(filtered) != 1 || filtered[0].Name != "mod-1.0.jar" {
+		t.Fatalf("expected secondary jars to be excluded, got %v", assetNames(filtered))
+	}
+}
+
+func TestChooseAssetSingleMatch(t *testing.T) {
+	assets := []Asset{{Name: "mod-1.0.jar"}}
+	asset, err := chooseAsset(assets, "", "", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if asset.Name != "mod-1.0.jar" {
+		t.Fatalf("expected mod-1.0.jar, got %s", asset.Name)
+	}
+}
+
+func TestChooseAssetYesFailsOnAmbiguity(t *testing.T) {
+	assets := []Asset{
+		{Name: "mod-fabric.jar"},
+		{Name: "mod-forge.jar"},
+	}
+
+	_, err := chooseAsset(assets, "", "", true)
+	if err == nil {
+		t.Fatal("expected an error when ambiguity remains in --yes mode")
+	}
+}
+
+func TestChooseAssetPatternDisambiguates(t *testing.T) {
+	assets := []Asset{
+		{Name: "mod-fabric.jar"},
+		{Name: "mod-forge.jar"},
+	}
+
+	asset, err := chooseAsset(assets, "*forge*", "", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if asset.Name != "mod-forge.jar" {
+		t.Fatalf("expected mod-forge.jar, got %s", asset.Name)
+	}
+}
+
+func TestChooseAssetPatternMatchesNonJarAsset(t *testing.T) {
+	assets := []Asset{
+		{Name: "mod-1.0.jar"},
+		{Name: "mod-1.0-source.zip"},
+	}
+
+	asset, err := chooseAsset(assets, "*.zip", "", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if asset.Name != "mod-1.0-source.zip" {
+		t.Fatalf("expected the jar pre-filter to be skipped when a pattern is given, got %s", asset.Name)
+	}
+}
+
+func TestChooseAssetNoMatches(t *testing.T) {
+	assets := []Asset{{Name: "mod.jar"}}
+	_, err := chooseAsset(assets, "*.zip", "", true)
+	if err == nil {
+		t.Fatal("expected an error when the pattern matches nothing")
+	}
+}
@@ -0,0 +1,292 @@
+package github
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/packwiz/packwiz/core"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+// defaultCatalogRepo is consulted when the user hasn't configured any catalogs of their own,
+// the same way a package manager falls back to a well-known default registry.
+const defaultCatalogRepo = "https://github.com/packwiz/packwiz-github-catalog"
+
+// catalogEntry is a single mod's entry in a catalog repo, one YAML file per mod. It turns a
+// GitHub install from "guess the jar" into a deterministic pick, the way hd-home's templated
+// asset names do for http-downloader.
+type catalogEntry struct {
+	Repo          string            `yaml:"repo"`
+	AssetTemplate string            `yaml:"asset_template"`
+	Side          string            `yaml:"side"`
+	Dependencies  []string          `yaml:"dependencies"`
+	LoaderMap     map[string]string `yaml:"loader_map"`
+}
+
+// catalogTemplateData is the set of pack values exposed to a catalog entry's asset_template.
+type catalogTemplateData struct {
+	Version   string
+	MCVersion string
+	Loader    string
+	OS        string
+}
+
+// catalogDir returns the local checkout directory for a configured catalog repo, under
+// packwiz's cache dir.
+func catalogDir(repoURL string) (string, error) {
+	cacheDir, err := cacheDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "catalogs", cacheKeyFor(repoURL)), nil
+}
+
+// configuredCatalogs returns the catalog repo URLs to consult: whatever is set under
+// `github.catalogs` in viper config, or defaultCatalogRepo if none are configured.
+func configuredCatalogs() []string {
+	catalogs := viper.GetStringSlice("github.catalogs")
+	if len(catalogs) == 0 {
+		return []string{defaultCatalogRepo}
+	}
+	return catalogs
+}
+
+// ensureCatalogCloned clones repoURL into its catalog dir if it isn't already checked out.
+func ensureCatalogCloned(repoURL string) (string, error) {
+	dir, err := catalogDir(repoURL)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		return dir, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command("git", "clone", "--depth", "1", repoURL, dir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to clone catalog %s: %v\n%s", repoURL, err, out)
+	}
+	return dir, nil
+}
+
+// findCatalogEntry searches the configured catalogs, in order, for a YAML entry matching
+// shortname (as "<shortname>.yml" or "<shortname>.yaml").
+func findCatalogEntry(shortname string) (catalogEntry, error) {
+	for _, repoURL := range configuredCatalogs() {
+		dir, err := ensureCatalogCloned(repoURL)
+		if err != nil {
+			fmt.Printf("Warning: skipping catalog %s: %v\n", repoURL, err)
+			continue
+		}
+
+		for _, ext := range []string{".yml", ".yaml"} {
+			data, err := os.ReadFile(filepath.Join(dir, shortname+ext))
+			if err != nil {
+				continue
+			}
+
+			var entry catalogEntry
+			if err := yaml.Unmarshal(data, &entry); err != nil {
+				return catalogEntry{}, fmt.Errorf("invalid catalog entry %s%s: %v", shortname, ext, err)
+			}
+			return entry, nil
+		}
+	}
+
+	return catalogEntry{}, fmt.Errorf("no catalog entry found for %q", shortname)
+}
+
+// resolveCatalogAssetPattern renders entry's asset_template against the pack's configured
+// versions/loader, yielding a glob/regex pattern suitable for chooseAsset.
+func resolveCatalogAssetPattern(entry catalogEntry, pack core.Pack) (string, error) {
+	tmpl, err := template.New("asset").Parse(entry.AssetTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid asset_template: %v", err)
+	}
+
+	loader, err := resolvePackLoader(pack)
+	if err != nil {
+		return "", err
+	}
+
+	loaderName := loader
+	if mapped, ok := entry.LoaderMap[loader]; ok {
+		loaderName = mapped
+	}
+
+	data := catalogTemplateData{
+		Version:   pack.Versions[loader],
+		MCVersion: pack.Versions["minecraft"],
+		Loader:    loaderName,
+		OS:        runtime.GOOS,
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render asset_template: %v", err)
+	}
+	return buf.String(), nil
+}
+
+// resolvePackLoader picks the single pack.Versions key (other than "minecraft") that names the
+// pack's configured mod loader. A pack is expected to have exactly one such key; ranging over
+// pack.Versions directly (as resolveCatalogAssetPattern used to) is non-deterministic, since Go
+// randomizes map iteration order, and more than one candidate would make the result ambiguous
+// anyway.
+func resolvePackLoader(pack core.Pack) (string, error) {
+	var candidates []string
+	for name := range pack.Versions {
+		if name != "minecraft" {
+			candidates = append(candidates, name)
+		}
+	}
+	sort.Strings(candidates)
+
+	if len(candidates) == 0 {
+		return "", errors.New("pack has no configured mod loader version")
+	}
+	if len(candidates) > 1 {
+		return "", fmt.Errorf("pack has multiple configured loader versions (%s); github catalog installs require exactly one", strings.Join(candidates, ", "))
+	}
+	return candidates[0], nil
+}
+
+// catalogCmd groups subcommands for managing configured GitHub catalogs.
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Manage configured GitHub catalogs",
+}
+
+// catalogUpdateCmd represents "github catalog update".
+var catalogUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Pull the latest changes for all configured GitHub catalogs",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, repoURL := range configuredCatalogs() {
+			dir, err := ensureCatalogCloned(repoURL)
+			if err != nil {
+				fmt.Printf("Failed to update catalog %s: %v\n", repoURL, err)
+				continue
+			}
+
+			cmd := exec.Command("git", "-C", dir, "pull", "--ff-only")
+			if out, err := cmd.CombinedOutput(); err != nil {
+				fmt.Printf("Failed to update catalog %s: %v\n%s\n", repoURL, err, out)
+				continue
+			}
+			fmt.Printf("Updated catalog %s\n", repoURL)
+		}
+	},
+}
+
+func init() {
+	catalogCmd.AddCommand(catalogUpdateCmd)
+	githubCmd.AddCommand(catalogCmd)
+}
+
+// installCatalogEntry installs the asset matching entry's asset_template for the current pack's
+// versions/loader, without prompting. Unlike findCatalogEntry (where failure just means "try the
+// raw slug path instead"), a failure here is a real, actionable error - the shortname *was*
+// found in a catalog - so callers should report it rather than silently falling back.
+//
+// An explicit assetPattern/prefer (from --asset-pattern/--prefer) overrides the catalog's own
+// asset_template/loader hint, the same as it would for a raw slug install - catalogs pick a
+// sensible default, they don't get the final say over an explicit user override.
+func installCatalogEntry(entry catalogEntry, pack core.Pack, assetPattern string, prefer string) error {
+	return installCatalogEntryTracked(entry, pack, assetPattern, prefer, map[string]bool{})
+}
+
+// installCatalogEntryTracked is installCatalogEntry with ancestors carrying the catalog
+// shortnames currently being installed along this dependencies chain, so a cyclic
+// dependencies: list fails with a clear error instead of recursing forever.
+func installCatalogEntryTracked(entry catalogEntry, pack core.Pack, assetPattern string, prefer string, ancestors map[string]bool) error {
+	if err := installCatalogDependencies(entry, pack, ancestors); err != nil {
+		return err
+	}
+
+	repo, err := fetchRepo(entry.Repo)
+	if err != nil {
+		return fmt.Errorf("failed to get the mod: %v", err)
+	}
+
+	pattern := assetPattern
+	if pattern == "" {
+		pattern, err = resolveCatalogAssetPattern(entry, pack)
+		if err != nil {
+			return err
+		}
+	}
+
+	latestVersion, err := getLatestVersion(repo.FullName, "")
+	if err != nil {
+		return fmt.Errorf("failed to get latest version: %v", err)
+	}
+
+	return installVersion(repo, latestVersion, pack, pattern, prefer, true, catalogSide(entry.Side))
+}
+
+// catalogSide maps a catalog entry's side string to the core.Mod.Side value it should install
+// with, defaulting to universal when the entry doesn't declare one.
+func catalogSide(side string) string {
+	if side == "" {
+		return core.UniversalSide
+	}
+	return side
+}
+
+// installCatalogDependencies installs each of entry's declared dependencies - also catalog
+// shortnames - the same way installCatalogEntry installs the entry itself, before the entry
+// is installed. A dependency that can't be resolved or installed fails the whole install,
+// since a declared dependency silently skipped would leave the pack incomplete without
+// anyone noticing. ancestors carries the shortnames already being installed along this chain,
+// so a cyclic dependencies: list (a catalog is external, community-maintained YAML, not
+// something packwiz controls) fails with a clear error instead of recursing forever.
+func installCatalogDependencies(entry catalogEntry, pack core.Pack, ancestors map[string]bool) error {
+	for _, dep := range entry.Dependencies {
+		depAncestors, err := advanceAncestors(ancestors, dep)
+		if err != nil {
+			return err
+		}
+
+		depEntry, err := findCatalogEntry(dep)
+		if err != nil {
+			return fmt.Errorf("failed to resolve dependency %q: %v", dep, err)
+		}
+		if err := installCatalogEntryTracked(depEntry, pack, "", "", depAncestors); err != nil {
+			return fmt.Errorf("failed to install dependency %q: %v", dep, err)
+		}
+	}
+	return nil
+}
+
+// advanceAncestors returns a copy of ancestors with dep added, or an error if dep is already
+// present - a dependency cycle. Copying rather than mutating ancestors in place means two
+// branches that legitimately depend on the same shortname (a diamond, not a cycle) each get
+// their own view of the chain above them, so a shared dependency isn't mistaken for a cycle.
+func advanceAncestors(ancestors map[string]bool, dep string) (map[string]bool, error) {
+	if ancestors[dep] {
+		return nil, fmt.Errorf("catalog dependency cycle detected at %q", dep)
+	}
+
+	next := make(map[string]bool, len(ancestors)+1)
+	for k := range ancestors {
+		next[k] = true
+	}
+	next[dep] = true
+	return next, nil
+}
@@ -0,0 +1,74 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/packwiz/packwiz/core"
+)
+
+func TestResolvePackLoaderSingleCandidate(t *testing.T) {
+	pack := core.Pack{Versions: map[string]string{"minecraft": "1.20.1", "fabric": "0.15.0"}}
+
+	loader, err := resolvePackLoader(pack)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loader != "fabric" {
+		t.Fatalf("expected loader %q, got %q", "fabric", loader)
+	}
+}
+
+func TestResolvePackLoaderNoCandidate(t *testing.T) {
+	pack := core.Pack{Versions: map[string]string{"minecraft": "1.20.1"}}
+
+	if _, err := resolvePackLoader(pack); err == nil {
+		t.Fatal("expected an error when the pack has no configured loader version")
+	}
+}
+
+func TestResolvePackLoaderAmbiguous(t *testing.T) {
+	pack := core.Pack{Versions: map[string]string{"minecraft": "1.20.1", "fabric": "0.15.0", "quilt": "0.20.0"}}
+
+	if _, err := resolvePackLoader(pack); err == nil {
+		t.Fatal("expected an error when the pack has multiple configured loader versions")
+	}
+}
+
+func TestAdvanceAncestorsDetectsCycle(t *testing.T) {
+	ancestors := map[string]bool{"a": true, "b": true}
+
+	if _, err := advanceAncestors(ancestors, "a"); err == nil {
+		t.Fatal("expected a cycle error")
+	}
+}
+
+func TestAdvanceAncestorsAllowsDiamond(t *testing.T) {
+	ancestors := map[string]bool{"a": true}
+
+	next, err := advanceAncestors(ancestors, "b")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !next["a"] || !next["b"] {
+		t.Fatal("expected both the original ancestor and the new dependency present")
+	}
+	if len(ancestors) != 1 {
+		t.Fatal("advanceAncestors must not mutate the caller's map")
+	}
+}
+
+func TestResolveCatalogAssetPatternUsesLoaderMap(t *testing.T) {
+	pack := core.Pack{Versions: map[string]string{"minecraft": "1.20.1", "fabric": "0.15.0"}}
+	entry := catalogEntry{
+		AssetTemplate: "mod-{{.Loader}}-{{.MCVersion}}.jar",
+		LoaderMap:     map[string]string{"fabric": "fabric-api"},
+	}
+
+	pattern, err := resolveCatalogAssetPattern(entry, pack)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "mod-fabric-api-1.20.1.jar"; pattern != want {
+		t.Fatalf("expected pattern %q, got %q", want, pattern)
+	}
+}
@@ -0,0 +1,271 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ghClient is a shared, rate-limit-aware GitHub API client. It authenticates requests when a
+// token is available (raising the 60 req/hr anonymous limit to 5000 req/hr), backs off when
+// the API says to, and caches responses on disk keyed by ETag/Last-Modified so repeated
+// `packwiz refresh`/`update` runs can reuse a cached body via a conditional request.
+type ghClient struct {
+	httpClient *http.Client
+	cacheDir   string
+}
+
+var ghDefaultClient = newGhClient()
+
+func newGhClient() *ghClient {
+	cacheDir, err := cacheDirPath()
+	if err != nil {
+		// Caching is a best-effort optimisation; fall back to an uncached client rather
+		// than failing every request.
+		cacheDir = ""
+	}
+
+	return &ghClient{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		cacheDir:   cacheDir,
+	}
+}
+
+// cacheDirPath returns the directory used to store cached GitHub API responses, analogous to
+// the download split of Go's module cache (GOMODCACHE/cache/download).
+func cacheDirPath() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, "packwiz", "github")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// githubToken resolves an API token from, in order: GITHUB_TOKEN, GH_TOKEN, the `gh` CLI's
+// stored credentials, or the `github.token` viper config key.
+func githubToken() string {
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+	if token := os.Getenv("GH_TOKEN"); token != "" {
+		return token
+	}
+	if token := viper.GetString("github.token"); token != "" {
+		return token
+	}
+	if out, err := exec.Command("gh", "auth", "token").Output(); err == nil {
+		if token := strings.TrimSpace(string(out)); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// cacheEntry is the on-disk record for a single cached URL: the validators needed to make a
+// conditional request, plus the body we'll reuse on a 304.
+type cacheEntry struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// makeGet performs a GET request against the GitHub API. path may be a full URL or a path
+// relative to githubApiUrl. Requests are authenticated when a token is available, and served
+// from the on-disk cache (via If-None-Match/If-Modified-Since) when the server returns 304.
+func (c *ghClient) makeGet(path string) (*http.Response, error) {
+	url := path
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		url = githubApiUrl + path
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token := githubToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	cacheKey := cacheKeyFor(url)
+	cached, hasCached := c.loadCacheEntry(cacheKey)
+	if hasCached {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := c.doWithBackoff(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		resp.Body.Close()
+		return newCachedResponse(cached.Body), nil
+	}
+
+	if resp.StatusCode == http.StatusOK {
+		c.saveCacheEntry(cacheKey, resp)
+	}
+
+	return resp, nil
+}
+
+// makeGetBinary performs an authenticated GET the same way makeGet does, but never reads the
+// response into the on-disk ETag cache: unlike the small JSON bodies makeGet is meant for, a
+// binary download (a release asset, an Actions artifact zip) can be many megabytes, and caching
+// one there would grow the cache without bound for something a conditional request gains
+// little from anyway.
+func (c *ghClient) makeGetBinary(path string) (*http.Response, error) {
+	url := path
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		url = githubApiUrl + path
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token := githubToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return c.doWithBackoff(req)
+}
+
+// doWithBackoff issues req, retrying once with a delay if GitHub's rate limiting tells us to.
+func (c *ghClient) doWithBackoff(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+		wait := backoffDuration(resp.Header)
+		if wait > 0 {
+			resp.Body.Close()
+			fmt.Printf("GitHub API rate limit hit, waiting %s before retrying...\n", wait)
+			time.Sleep(wait)
+			return c.httpClient.Do(req)
+		}
+	}
+
+	return resp, nil
+}
+
+// backoffDuration reads Retry-After, falling back to X-RateLimit-Reset, to work out how long
+// to wait before a rate-limited request can be retried. It returns 0 if neither header gives
+// us anything actionable.
+func backoffDuration(header http.Header) time.Duration {
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	if remaining := header.Get("X-RateLimit-Remaining"); remaining == "0" {
+		if reset := header.Get("X-RateLimit-Reset"); reset != "" {
+			if resetUnix, err := strconv.ParseInt(reset, 10, 64); err == nil {
+				wait := time.Until(time.Unix(resetUnix, 0))
+				if wait > 0 {
+					return wait
+				}
+			}
+		}
+	}
+
+	return 0
+}
+
+func cacheKeyFor(url string) string {
+	return strings.NewReplacer("/", "_", ":", "_", "?", "_", "&", "_").Replace(url)
+}
+
+func (c *ghClient) cacheFilePath(key string) string {
+	if c.cacheDir == "" {
+		return ""
+	}
+	return filepath.Join(c.cacheDir, key+".json")
+}
+
+func (c *ghClient) loadCacheEntry(key string) (cacheEntry, bool) {
+	path := c.cacheFilePath(key)
+	if path == "" {
+		return cacheEntry{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *ghClient) saveCacheEntry(key string, resp *http.Response) {
+	path := c.cacheFilePath(key)
+	if path == "" {
+		return
+	}
+
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return
+	}
+
+	body, err := readAndRestoreBody(resp)
+	if err != nil {
+		return
+	}
+
+	entry := cacheEntry{ETag: etag, LastModified: lastModified, Body: body}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
+
+// readAndRestoreBody reads resp.Body fully, then replaces it with a fresh reader over the
+// same bytes so callers can still consume it normally after we've cached a copy.
+func readAndRestoreBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// newCachedResponse builds a synthetic 200 response carrying a previously-cached body, so
+// that a 304 from the API is transparent to makeGet's callers.
+func newCachedResponse(body []byte) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK (cached)",
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
@@ -0,0 +1,104 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+// githubCmd groups every GitHub-backed install strategy (release asset, source checkout,
+// workflow artifact, catalog) as `packwiz github <subcommand>`; each strategy registers its
+// own subcommand from its own file's init().
+var githubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "Manage GitHub-based mods",
+}
+
+// Repo is a GitHub repository, as returned by the repos API.
+type Repo struct {
+	FullName string `json:"full_name"`
+	Name     string `json:"name"`
+}
+
+// Release is a GitHub release, as returned by the releases API.
+type Release struct {
+	URL             string  `json:"html_url"`
+	TagName         string  `json:"tag_name"`
+	TargetCommitish string  `json:"target_commitish"`
+	Assets          []Asset `json:"assets"`
+}
+
+// Asset is a single file attached to a release.
+type Asset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// getSha256 downloads a's content and returns its sha256, to record as Download.Hash at
+// install time. Downloaded directly rather than through ghDefaultClient - release assets can
+// be many megabytes and don't need an authenticated or cached request the way API calls do.
+func (a Asset) getSha256() (string, error) {
+	resp, err := http.Get(a.BrowserDownloadURL)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download asset: status %s", resp.Status)
+	}
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, resp.Body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// fetchRepo resolves slug (e.g. "owner/repo") against the GitHub API's repo endpoint.
+func fetchRepo(slug string) (Repo, error) {
+	var repo Repo
+
+	resp, err := ghDefaultClient.makeGet("repos/" + slug)
+	if err != nil {
+		return repo, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return repo, err
+	}
+	if err := json.Unmarshal(body, &repo); err != nil {
+		return repo, err
+	}
+	return repo, nil
+}
+
+// ghUpdateData is the `update` metadata stored under the "github" key for a mod installed
+// either from a release asset (chooseAsset's pick) or, when Source is set, from a pinned
+// source checkout (see sourceOrigin). AssetPattern is carried forward so a release-pinned
+// mod's update can pick the equivalent asset on a newer release rather than silently
+// switching files; Source is carried forward so a source-pinned mod's update can tell whether
+// the ref it tracks has moved.
+type ghUpdateData struct {
+	Slug         string        `json:"slug"`
+	Tag          string        `json:"tag"`
+	Branch       string        `json:"branch"`
+	AssetPattern string        `json:"assetPattern,omitempty"`
+	Source       *sourceOrigin `json:"source,omitempty"`
+}
+
+func (u ghUpdateData) ToMap() (map[string]interface{}, error) {
+	var m map[string]interface{}
+	data, err := json.Marshal(u)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &m)
+	return m, err
+}
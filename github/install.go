@@ -35,6 +35,39 @@ var installCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		assetPattern, err := cmd.Flags().GetString("asset-pattern")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		prefer, err := cmd.Flags().GetString("prefer")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		yes, err := cmd.Flags().GetBool("yes")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		// A bare shortname (no slashes, not a GitHub URL) is tried against the configured
+		// catalogs first, for a deterministic, no-prompt install. Only "not found in any
+		// catalog" falls back to the raw slug path below - any other failure (a bad
+		// asset_template, an ambiguous pack loader, a network error) is the shortname's real
+		// install failure and should be reported, not masked behind a confusing slug lookup.
+		// --asset-pattern/--prefer still override the catalog's own asset_template/loader hint
+		// when given, the same as they would for a raw slug install.
+		if !strings.Contains(args[0], "/") {
+			if entry, err := findCatalogEntry(args[0]); err == nil {
+				if err := installCatalogEntry(entry, pack, assetPattern, prefer); err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+				return
+			}
+		}
+
 		// Try interpreting the argument as a slug, or GitHub repository URL.
 		var slug string
 
@@ -54,17 +87,24 @@ var installCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		installMod(repo, pack)
+		err = installMod(repo, pack, assetPattern, prefer, yes)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
 	},
 }
 
 func init() {
+	installCmd.Flags().String("asset-pattern", "", "Glob or regex (matched against the release asset file name) to select which asset to install")
+	installCmd.Flags().String("prefer", "", "Substring hint to prefer when multiple assets match (e.g. \"fabric\", \"forge\", \"-sources\")")
+	installCmd.Flags().Bool("yes", false, "Don't prompt when multiple assets match; fail instead")
 	githubCmd.AddCommand(installCmd)
 }
 
 const githubApiUrl = "https://api.github.com/"
 
-func installMod(repo Repo, pack core.Pack) error {
+func installMod(repo Repo, pack core.Pack, assetPattern string, prefer string, yes bool) error {
 	latestVersion, err := getLatestVersion(repo.FullName, "")
 	if err != nil {
 		return fmt.Errorf("failed to get latest version: %v", err)
@@ -73,7 +113,7 @@ func installMod(repo Repo, pack core.Pack) error {
 		return errors.New("mod is not available for this Minecraft version (use the acceptable-game-versions option to accept more) or mod loader")
 	}
 
-	return installVersion(repo, latestVersion, pack)
+	return installVersion(repo, latestVersion, pack, assetPattern, prefer, yes, core.UniversalSide)
 }
 
 func getLatestVersion(slug string, branch string) (Release, error) {
@@ -103,19 +143,10 @@ func getLatestVersion(slug string, branch string) (Release, error) {
 	return modReleases[0], nil
 }
 
-func installVersion(repo Repo, release Release, pack core.Pack) error {
-	var files = release.Assets
-
-	if len(files) == 0 {
-		return errors.New("release doesn't have any files attached")
-	}
-
-	// TODO: add some way to allow users to pick which file to install?
-	var file = files[0]
-	for _, v := range release.Assets {
-		if strings.HasSuffix(v.Name, ".jar") {
-			file = v
-		}
+func installVersion(repo Repo, release Release, pack core.Pack, assetPattern string, prefer string, yes bool, side string) error {
+	file, err := chooseAsset(release.Assets, assetPattern, prefer, yes)
+	if err != nil {
+		return err
 	}
 
 	//Install the file
@@ -128,9 +159,10 @@ func installVersion(repo Repo, release Release, pack core.Pack) error {
 	updateMap := make(map[string]map[string]interface{})
 
 	updateMap["github"], err = ghUpdateData{
-		Slug:   repo.FullName,
-		Tag:    release.TagName,
-		Branch: release.TargetCommitish,
+		Slug:         repo.FullName,
+		Tag:          release.TagName,
+		Branch:       release.TargetCommitish,
+		AssetPattern: assetPattern,
 	}.ToMap()
 	if err != nil {
 		return err
@@ -144,7 +176,7 @@ func installVersion(repo Repo, release Release, pack core.Pack) error {
 	modMeta := core.Mod{
 		Name:     repo.Name,
 		FileName: file.Name,
-		Side:     core.UniversalSide,
+		Side:     side,
 		Download: core.ModDownload{
 			URL:        file.BrowserDownloadURL,
 			HashFormat: "sha256",
@@ -0,0 +1,31 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+// writeModFile materializes content as name inside the pack's configured mod folder. It's used
+// where the installed bytes are extracted/unpacked from a larger download (an archive subtree, a
+// zipped artifact's jar) and so can't be left for a generic URL-based fetch to reproduce - we
+// place the real file ourselves instead. The caller still records Download.Hash against these
+// exact bytes (so a later `refresh` verifies what's actually on disk), even though a fresh
+// `install` re-fetching Download.URL would get the whole archive rather than this subset of it.
+func writeModFile(folder string, name string, content []byte) error {
+	dir := filepath.Join(viper.GetString("meta-folder-base"), folder)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name), content, 0644)
+}
+
+// modFolder returns the mod folder configured via --meta-folder, defaulting to "mods".
+func modFolder() string {
+	folder := viper.GetString("meta-folder")
+	if folder == "" {
+		folder = "mods"
+	}
+	return folder
+}
@@ -0,0 +1,321 @@
+package github
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/packwiz/packwiz/core"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// sourceOrigin mirrors the Origin block that `go mod download` attaches to module cache
+// entries: enough provenance (repo, ref, resolved commit) to tell, cheaply, whether a
+// re-fetch would produce anything different.
+type sourceOrigin struct {
+	URL     string `json:"url"`
+	Ref     string `json:"ref,omitempty"`
+	Hash    string `json:"hash"`
+	Subpath string `json:"subpath,omitempty"`
+}
+
+// installSource installs repo by archiving a specific ref (tag, branch, or commit SHA)
+// rather than a release asset. It's used as a fallback for projects that publish releases
+// without any attached jars, where the "source" of truth is a pinned git revision instead.
+func installSource(repo Repo, ref string, subdir string, pack core.Pack) error {
+	folder := modFolder()
+
+	sha, hash, fileName, downloadURL, err := materializeSource(repo.FullName, repo.Name, ref, subdir, folder)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Installing %s from %s (%s)\n", repo.FullName, ref, sha[:12])
+
+	index, err := pack.LoadIndex()
+	if err != nil {
+		return err
+	}
+
+	origin := sourceOrigin{
+		URL:     fmt.Sprintf("https://github.com/%s", repo.FullName),
+		Ref:     ref,
+		Hash:    sha,
+		Subpath: subdir,
+	}
+
+	updateMap := make(map[string]map[string]interface{})
+	updateMap["github"], err = ghUpdateData{
+		Slug:   repo.FullName,
+		Source: &origin,
+	}.ToMap()
+	if err != nil {
+		return err
+	}
+
+	modMeta := core.Mod{
+		Name:     repo.Name,
+		FileName: fileName,
+		Side:     core.UniversalSide,
+		Download: core.ModDownload{
+			URL:        downloadURL,
+			HashFormat: "sha256",
+			Hash:       hash,
+		},
+		Update: updateMap,
+	}
+
+	path := modMeta.SetMetaPath(filepath.Join(viper.GetString("meta-folder-base"), folder, repo.Name+core.MetaExtension))
+
+	format, hash, err := modMeta.Write()
+	if err != nil {
+		return err
+	}
+	err = index.RefreshFileWithHash(path, format, hash, true)
+	if err != nil {
+		return err
+	}
+	err = index.Write()
+	if err != nil {
+		return err
+	}
+	err = pack.UpdateIndexHash()
+	if err != nil {
+		return err
+	}
+	return pack.Write()
+}
+
+// materializeSource resolves ref on slug, downloads the resulting tarball, and (when subdir
+// is set) extracts just that subtree into the pack's mod folder. It returns the resolved commit
+// SHA, the installed file's name, and the download metadata (URL/hash) describing what's
+// actually on disk - when subdir is set, that's the extracted subtree, not the full tarball, so
+// the hash is taken over the materialized bytes rather than the tarball codeload actually
+// serves at archiveURL. Shared between installSource and updateGithubSource so a fresh install
+// and a later update resolve a ref identically.
+func materializeSource(slug string, repoName string, ref string, subdir string, folder string) (sha string, hash string, fileName string, downloadURL string, err error) {
+	sha, err = resolveRef(slug, ref)
+	if err != nil {
+		return "", "", "", "", fmt.Errorf("failed to resolve ref %q: %v", ref, err)
+	}
+
+	archiveURL := fmt.Sprintf("https://codeload.github.com/%s/tar.gz/%s", slug, sha)
+
+	tmpFile, err := os.CreateTemp("", "packwiz-github-source-*.tar.gz")
+	if err != nil {
+		return "", "", "", "", err
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	resp, err := http.Get(archiveURL)
+	if err != nil {
+		return "", "", "", "", fmt.Errorf("failed to download source archive: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", "", "", fmt.Errorf("failed to download source archive: status %s", resp.Status)
+	}
+
+	tarballHasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmpFile, tarballHasher), resp.Body); err != nil {
+		return "", "", "", "", fmt.Errorf("failed to save source archive: %v", err)
+	}
+
+	if subdir != "" {
+		content, subName, err := extractSubdir(tmpFile.Name(), subdir)
+		if err != nil {
+			return "", "", "", "", fmt.Errorf("failed to materialize subdir %q: %v", subdir, err)
+		}
+		if err := writeModFile(folder, subName, content); err != nil {
+			return "", "", "", "", fmt.Errorf("failed to write %q: %v", subName, err)
+		}
+
+		subHasher := sha256.New()
+		subHasher.Write(content)
+		return sha, hex.EncodeToString(subHasher.Sum(nil)), subName, archiveURL, nil
+	}
+
+	// No subdir: the full tarball codeload serves at archiveURL is exactly what's installed,
+	// so it can be recorded as a normal fetchable download.
+	return sha, hex.EncodeToString(tarballHasher.Sum(nil)), fmt.Sprintf("%s-%s.tar.gz", repoName, sha[:12]), archiveURL, nil
+}
+
+// resolveRef asks the GitHub API to resolve a tag, branch, or (already-resolved) commit SHA
+// on slug to a full commit SHA, the same way `go get` pins a module to a specific rev.
+func resolveRef(slug string, ref string) (string, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	resp, err := ghDefaultClient.makeGet(fmt.Sprintf("repos/%s/commits/%s", slug, ref))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GitHub API returned status %s while resolving ref %q", resp.Status, ref)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	if err := json.Unmarshal(body, &commit); err != nil {
+		return "", err
+	}
+	if commit.SHA == "" {
+		return "", errors.New("GitHub API did not return a commit SHA")
+	}
+	return commit.SHA, nil
+}
+
+// extractSubdir reads the tar.gz at tarGzPath and returns just the entries under subdir
+// (relative to the archive's single top-level directory, the way codeload.github.com always
+// packages it). A subdir naming a single file returns that file's bytes verbatim; a subdir
+// naming a directory is repackaged as its own tar.gz rooted at the directory's contents.
+func extractSubdir(tarGzPath string, subdir string) ([]byte, string, error) {
+	f, err := os.Open(tarGzPath)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, "", err
+	}
+	defer gzr.Close()
+
+	type subEntry struct {
+		relPath string
+		data    []byte
+	}
+	var entries []subEntry
+
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Archive entries are rooted under a single "<repo>-<sha>/" directory; strip it.
+		parts := strings.SplitN(hdr.Name, "/", 2)
+		if len(parts) < 2 || parts[1] == "" {
+			continue
+		}
+		rel := parts[1]
+		if rel != subdir && !strings.HasPrefix(rel, subdir+"/") {
+			continue
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, "", err
+		}
+		entries = append(entries, subEntry{relPath: rel, data: data})
+	}
+
+	if len(entries) == 0 {
+		return nil, "", fmt.Errorf("no entries found under %q", subdir)
+	}
+
+	if len(entries) == 1 && entries[0].relPath == subdir {
+		return entries[0].data, filepath.Base(subdir), nil
+	}
+
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for _, e := range entries {
+		name := strings.TrimPrefix(strings.TrimPrefix(e.relPath, subdir), "/")
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(e.data))}); err != nil {
+			return nil, "", err
+		}
+		if _, err := tw.Write(e.data); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, "", err
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), filepath.Base(subdir) + ".tar.gz", nil
+}
+
+// sourceCmd represents the "github source" command, which installs a mod by pinning a git
+// ref rather than downloading a release asset - for repos that don't publish jars at all.
+var sourceCmd = &cobra.Command{
+	Use:   "source [URL] [ref]",
+	Short: "Add a project from a GitHub repository by pinning a git ref (for repos with no release assets)",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		pack, err := core.LoadPack()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		var slug string
+		matches := GithubRegex.FindStringSubmatch(args[0])
+		if len(matches) == 2 {
+			slug = matches[1]
+		} else {
+			slug = args[0]
+		}
+
+		ref := "HEAD"
+		if len(args) == 2 {
+			ref = args[1]
+		}
+
+		repo, err := fetchRepo(slug)
+		if err != nil {
+			fmt.Println("Failed to get the mod ", err)
+			os.Exit(1)
+		}
+
+		subdir, err := cmd.Flags().GetString("subdir")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		err = installSource(repo, ref, subdir, pack)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	sourceCmd.Flags().String("subdir", "", "Only materialize this subtree of the archive as the mod file")
+	githubCmd.AddCommand(sourceCmd)
+}
@@ -0,0 +1,114 @@
+package github
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"testing"
+)
+
+// writeTestTarGz builds a tar.gz with a single top-level "<root>/" directory containing the
+// given files, mirroring how codeload.github.com packages a repository archive.
+func writeTestTarGz(t *testing.T, root string, files map[string]string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+
+	for name, content := range files {
+		name = root + "/" + name
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatalf("failed to write tar header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write tar content: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar writer: %v", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("failed to close gzip writer: %v", err)
+	}
+
+	f, err := os.CreateTemp("", "packwiz-github-source-test-*.tar.gz")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	t.Cleanup(func() { os.Remove(f.Name()) })
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	f.Close()
+
+	return f.Name()
+}
+
+func TestExtractSubdirSingleFile(t *testing.T) {
+	path := writeTestTarGz(t, "mod-abc1234", map[string]string{
+		"build/libs/mod.jar": "jar-bytes",
+		"README.md":          "readme",
+	})
+
+	data, name, err := extractSubdir(path, "build/libs/mod.jar")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "mod.jar" {
+		t.Fatalf("expected name mod.jar, got %s", name)
+	}
+	if string(data) != "jar-bytes" {
+		t.Fatalf("expected jar-bytes, got %s", data)
+	}
+}
+
+func TestExtractSubdirDirectory(t *testing.T) {
+	path := writeTestTarGz(t, "mod-abc1234", map[string]string{
+		"dist/mod.jar":  "jar-bytes",
+		"dist/mod.json": "meta",
+		"README.md":     "readme",
+	})
+
+	data, name, err := extractSubdir(path, "dist")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "dist.tar.gz" {
+		t.Fatalf("expected name dist.tar.gz, got %s", name)
+	}
+
+	gzr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("repackaged archive isn't valid gzip: %v", err)
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+
+	found := map[string]bool{}
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		found[hdr.Name] = true
+	}
+	if !found["mod.jar"] || !found["mod.json"] {
+		t.Fatalf("expected repackaged archive to contain mod.jar and mod.json, got %v", found)
+	}
+	if found["README.md"] {
+		t.Fatalf("did not expect README.md outside the subdir to be included")
+	}
+}
+
+func TestExtractSubdirNotFound(t *testing.T) {
+	path := writeTestTarGz(t, "mod-abc1234", map[string]string{
+		"README.md": "readme",
+	})
+
+	_, _, err := extractSubdir(path, "does/not/exist")
+	if err == nil {
+		t.Fatal("expected an error for a missing subdir")
+	}
+}
@@ -0,0 +1,278 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/packwiz/packwiz/core"
+)
+
+func init() {
+	core.Updaters["github"] = githubUpdater{}
+	core.Updaters["github-artifact"] = githubArtifactUpdater{}
+}
+
+// decodeUpdateData round-trips mod's update block for key through JSON into dest, the same
+// encoding ToMap() used to produce it in the first place.
+func decodeUpdateData(mod core.Mod, key string, dest interface{}) error {
+	raw, ok := mod.Update[key]
+	if !ok {
+		return fmt.Errorf("mod %q has no %q update metadata", mod.Name, key)
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// githubUpdateResult is everything a pending "github" update needs DoUpdate to apply: the
+// replacement FileName/Download/Update already resolved during CheckUpdate, so DoUpdate itself
+// never has to touch the network - it just writes the resolved fields onto the mod.
+type githubUpdateResult struct {
+	FileName string
+	Download core.ModDownload
+	Update   map[string]map[string]interface{}
+}
+
+// githubUpdater implements core.Updater for mods installed via `github add` (pinned to a
+// release, identified by tag) or `github source` (pinned to a git ref, identified by commit
+// SHA) - both recorded under the "github" update key, since ghUpdateData.Source is what
+// distinguishes the two once parsed.
+type githubUpdater struct{}
+
+func (githubUpdater) ParseUpdate(raw map[string]interface{}) (interface{}, error) {
+	var data ghUpdateData
+	buf, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	return data, json.Unmarshal(buf, &data)
+}
+
+func (githubUpdater) CheckUpdate(mods []*core.Mod, mcVersion string, pack core.Pack) ([]core.UpdateCheck, error) {
+	checks := make([]core.UpdateCheck, len(mods))
+	for i, mod := range mods {
+		var data ghUpdateData
+		if err := decodeUpdateData(*mod, "github", &data); err != nil {
+			checks[i] = core.UpdateCheck{Error: err}
+			continue
+		}
+
+		if data.Source != nil {
+			checks[i] = checkGithubSourceUpdate(mod.Name, data)
+			continue
+		}
+		checks[i] = checkGithubReleaseUpdate(data)
+	}
+	return checks, nil
+}
+
+func (githubUpdater) DoUpdate(mods []*core.Mod, cachedState []interface{}) error {
+	for i, mod := range mods {
+		result, ok := cachedState[i].(githubUpdateResult)
+		if !ok {
+			continue
+		}
+		mod.FileName = result.FileName
+		mod.Download = result.Download
+		mod.Update = result.Update
+	}
+	return nil
+}
+
+// checkGithubReleaseUpdate reuses data's previously chosen AssetPattern so the update picks the
+// equivalent asset on the new release rather than silently switching files.
+func checkGithubReleaseUpdate(data ghUpdateData) core.UpdateCheck {
+	repo, err := fetchRepo(data.Slug)
+	if err != nil {
+		return core.UpdateCheck{Error: fmt.Errorf("failed to get the mod: %v", err)}
+	}
+
+	release, err := getLatestVersion(repo.FullName, data.Branch)
+	if err != nil {
+		return core.UpdateCheck{Error: fmt.Errorf("failed to get latest version: %v", err)}
+	}
+
+	if release.TagName == data.Tag {
+		return core.UpdateCheck{UpdateAvailable: false}
+	}
+
+	file, err := chooseAsset(release.Assets, data.AssetPattern, "", true)
+	if err != nil {
+		return core.UpdateCheck{Error: fmt.Errorf("failed to pick an equivalent asset on the new release: %v", err)}
+	}
+
+	hash, err := file.getSha256()
+	if err != nil {
+		return core.UpdateCheck{Error: err}
+	}
+
+	updateMap := make(map[string]map[string]interface{})
+	updateMap["github"], err = ghUpdateData{
+		Slug:         data.Slug,
+		Tag:          release.TagName,
+		Branch:       data.Branch,
+		AssetPattern: data.AssetPattern,
+	}.ToMap()
+	if err != nil {
+		return core.UpdateCheck{Error: err}
+	}
+
+	return core.UpdateCheck{
+		UpdateAvailable: true,
+		UpdateString:    fmt.Sprintf("%s -> %s", data.Tag, release.TagName),
+		CachedState: githubUpdateResult{
+			FileName: file.Name,
+			Download: core.ModDownload{
+				URL:        file.BrowserDownloadURL,
+				HashFormat: "sha256",
+				Hash:       hash,
+			},
+			Update: updateMap,
+		},
+	}
+}
+
+// checkGithubSourceUpdate re-resolves a `github source` mod's ref and compares the resulting
+// commit SHA against the one recorded in data.Source.Hash, so an unchanged ref (the common
+// case - most refs are tags or pinned commits) is a cheap no-op rather than a re-download.
+func checkGithubSourceUpdate(modName string, data ghUpdateData) core.UpdateCheck {
+	sha, err := resolveRef(data.Slug, data.Source.Ref)
+	if err != nil {
+		return core.UpdateCheck{Error: fmt.Errorf("failed to resolve ref %q: %v", data.Source.Ref, err)}
+	}
+
+	if sha == data.Source.Hash {
+		return core.UpdateCheck{UpdateAvailable: false}
+	}
+
+	sha, hash, fileName, downloadURL, err := materializeSource(data.Slug, modName, data.Source.Ref, data.Source.Subpath, modFolder())
+	if err != nil {
+		return core.UpdateCheck{Error: err}
+	}
+
+	origin := sourceOrigin{
+		URL:     data.Source.URL,
+		Ref:     data.Source.Ref,
+		Hash:    sha,
+		Subpath: data.Source.Subpath,
+	}
+
+	updateMap := make(map[string]map[string]interface{})
+	updateMap["github"], err = ghUpdateData{
+		Slug:   data.Slug,
+		Source: &origin,
+	}.ToMap()
+	if err != nil {
+		return core.UpdateCheck{Error: err}
+	}
+
+	return core.UpdateCheck{
+		UpdateAvailable: true,
+		UpdateString:    fmt.Sprintf("%s -> %s", data.Source.Hash[:12], sha[:12]),
+		CachedState: githubUpdateResult{
+			FileName: fileName,
+			Download: core.ModDownload{
+				URL:        downloadURL,
+				HashFormat: "sha256",
+				Hash:       hash,
+			},
+			Update: updateMap,
+		},
+	}
+}
+
+// githubArtifactUpdateResult is everything a pending "github-artifact" update needs DoUpdate
+// to apply, resolved up front during CheckUpdate the same way githubUpdateResult is.
+type githubArtifactUpdateResult struct {
+	FileName string
+	Download core.ModDownload
+	Update   map[string]map[string]interface{}
+}
+
+// githubArtifactUpdater implements core.Updater for mods installed via `github add-artifact`,
+// recorded under the "github-artifact" key - kept separate from "github" since an artifact's
+// metadata (run/workflow) is structurally incompatible with a release/source install's.
+type githubArtifactUpdater struct{}
+
+func (githubArtifactUpdater) ParseUpdate(raw map[string]interface{}) (interface{}, error) {
+	var data ghArtifactUpdateData
+	buf, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	return data, json.Unmarshal(buf, &data)
+}
+
+// CheckUpdate always re-resolves the latest successful workflow run from scratch, never
+// reusing the previously recorded run/artifact URL: artifact download URLs expire after 90
+// days, so even a mod whose run hasn't changed needs a freshly-issued URL.
+func (githubArtifactUpdater) CheckUpdate(mods []*core.Mod, mcVersion string, pack core.Pack) ([]core.UpdateCheck, error) {
+	checks := make([]core.UpdateCheck, len(mods))
+	for i, mod := range mods {
+		var data ghArtifactUpdateData
+		if err := decodeUpdateData(*mod, "github-artifact", &data); err != nil {
+			checks[i] = core.UpdateCheck{Error: err}
+			continue
+		}
+
+		run, art, jarHash, jarContent, jarName, err := resolveArtifact(data.Slug, data.Workflow, data.Branch, data.ArtifactName)
+		if err != nil {
+			checks[i] = core.UpdateCheck{Error: err}
+			continue
+		}
+
+		if run.ID == data.RunID && art.Name == data.ArtifactName {
+			checks[i] = core.UpdateCheck{UpdateAvailable: false}
+			continue
+		}
+
+		if err := writeModFile(modFolder(), jarName, jarContent); err != nil {
+			checks[i] = core.UpdateCheck{Error: fmt.Errorf("failed to write %q: %v", jarName, err)}
+			continue
+		}
+
+		updateMap := make(map[string]map[string]interface{})
+		updateMap["github-artifact"], err = ghArtifactUpdateData{
+			Slug:         data.Slug,
+			Workflow:     data.Workflow,
+			Branch:       data.Branch,
+			RunID:        run.ID,
+			ArtifactName: art.Name,
+		}.ToMap()
+		if err != nil {
+			checks[i] = core.UpdateCheck{Error: err}
+			continue
+		}
+
+		checks[i] = core.UpdateCheck{
+			UpdateAvailable: true,
+			UpdateString:    fmt.Sprintf("run #%d -> run #%d", data.RunID, run.ID),
+			CachedState: githubArtifactUpdateResult{
+				FileName: jarName,
+				Download: core.ModDownload{
+					URL:        art.ArchiveDownloadURL,
+					HashFormat: "sha256",
+					Hash:       jarHash,
+				},
+				Update: updateMap,
+			},
+		}
+	}
+	return checks, nil
+}
+
+func (githubArtifactUpdater) DoUpdate(mods []*core.Mod, cachedState []interface{}) error {
+	for i, mod := range mods {
+		result, ok := cachedState[i].(githubArtifactUpdateResult)
+		if !ok {
+			continue
+		}
+		mod.FileName = result.FileName
+		mod.Download = result.Download
+		mod.Update = result.Update
+	}
+	return nil
+}